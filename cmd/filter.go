@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/hysios/todo/parser"
+	"github.com/hysios/todo/printer"
+	"github.com/spf13/cobra"
+)
+
+var flattenFilter bool
+
+// filterCmd runs a parser.Query against one or more todo files and prints
+// the pruned result, e.g.:
+//
+//	todo filter "status:pending and (@critical or @today) and not @done"
+var filterCmd = &cobra.Command{
+	Use:   "filter <query>",
+	Short: "Filter todo items by a query expression",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		q, err := parser.ParseQuery(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		files := inputs
+		if len(files) == 0 {
+			cwd, err := os.Getwd()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			files, err = lookupTodos(cwd)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		for _, todoname := range files {
+			todo, err := parseTodo(todoname)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			filtered := q.Filter(todo, flattenFilter)
+
+			print := printer.New(filtered)
+			applyConfiguredTheme(print)
+			f, err := formatterFor(format, print)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if err := f.Format(filtered, os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+		}
+	},
+}
+
+func init() {
+	filterCmd.Flags().BoolVar(&flattenFilter, "flatten", false, "promote matched items to the top level instead of keeping their parent chain")
+	rootCmd.AddCommand(filterCmd)
+}
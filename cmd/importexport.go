@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hysios/todo/parser"
+	"github.com/hysios/todo/parser/ical"
+	"github.com/hysios/todo/parser/todotxt"
+	"github.com/spf13/cobra"
+)
+
+var importFrom string
+
+// importCmd converts a file in another format into the native one and
+// prints it through the normal formatter pipeline, e.g.:
+//
+//	todo import --from=todotxt file.txt
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a todo list from another format",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		f, err := os.Open(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+
+		todo, err := importTodo(importFrom, f)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printTodo(todo)
+	},
+}
+
+func importTodo(format string, f *os.File) (*parser.Todofile, error) {
+	switch format {
+	case "todotxt":
+		return todotxt.Parse(f)
+	default:
+		return nil, fmt.Errorf("import: unknown --from %q (want todotxt)", format)
+	}
+}
+
+var exportTo string
+
+// exportCmd converts a native todo file into another format, e.g.:
+//
+//	todo export --to=ical TODO > todos.ics
+var exportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Export a todo list to another format",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		todo, err := parseTodo(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := exportTodo(exportTo, todo); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func exportTodo(format string, todo *parser.Todofile) error {
+	switch format {
+	case "ical":
+		return ical.Export(os.Stdout, todo)
+	case "todotxt":
+		return todotxt.Write(os.Stdout, todo)
+	default:
+		return fmt.Errorf("export: unknown --to %q (want ical or todotxt)", format)
+	}
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFrom, "from", "todotxt", "source format: todotxt")
+	exportCmd.Flags().StringVar(&exportTo, "to", "ical", "target format: ical, todotxt")
+	rootCmd.AddCommand(importCmd, exportCmd)
+}
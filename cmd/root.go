@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -39,6 +39,8 @@ var (
 	inputs     []string
 	autoNumber bool
 	rewrite    bool
+	format     string
+	track      bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -60,6 +62,13 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
+		if watch {
+			if err := watchTodos(inputs); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
 		for _, todoname := range inputs {
 			todo, err := parseTodo(todoname)
 			if err != nil {
@@ -126,11 +135,42 @@ func printTodo(todo *parser.Todofile) {
 		num   int = 1
 		print     = printer.New(todo)
 	)
+	applyConfiguredTheme(print)
 
 	if autoNumber {
 		print.AddPipe(regeneratorNumber(num))
 	}
-	print.Print()
+	if track {
+		print.AddPipe(printer.TimeTrackPipe())
+	}
+
+	f, err := formatterFor(format, print)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := f.Format(todo, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// formatterFor resolves the --format flag to a printer.Formatter, reusing
+// print's palette and pipes for the color/plain backends.
+func formatterFor(format string, print *printer.Printer) (printer.Formatter, error) {
+	switch format {
+	case "color":
+		return print.ColorFormatter(), nil
+	case "plain":
+		return print.PlainFormatter(), nil
+	case "json":
+		return &printer.JSONFormatter{Indent: "  "}, nil
+	case "md", "markdown":
+		return &printer.MarkdownFormatter{}, nil
+	case "html":
+		return printer.NewHTMLFormatter(print.Palette), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (want color, plain, json, md, or html)", format)
+	}
 }
 
 func regeneratorNumber(num int) printer.PrinterFunc {
@@ -186,6 +226,9 @@ func rewriteTodo(filename string, todo *parser.Todofile) error {
 	if autoNumber {
 		print.AddPipe(regeneratorNumber(num))
 	}
+	if track {
+		print.AddPipe(printer.TimeTrackPipe())
+	}
 
 	print.WriteTo(f)
 	w.Flush()
@@ -200,6 +243,9 @@ func init() {
 	rootCmd.PersistentFlags().StringSliceVarP(&inputs, "input", "i", nil, "todo file input list")
 	rootCmd.PersistentFlags().BoolVarP(&autoNumber, "auto-number", "n", false, "auto numbering todo items")
 	rootCmd.PersistentFlags().BoolVarP(&rewrite, "rewrite", "w", false, "rewrite todolist to file")
+	rootCmd.PersistentFlags().StringVarP(&format, "format", "f", "color", "output format: color, plain, json, md, html")
+	rootCmd.PersistentFlags().BoolVar(&track, "track", false, "compute @lasted from @started/@done and show duration roll-ups")
+	rootCmd.PersistentFlags().StringVar(&theme, "theme", "", "named palette from themes.<name> in config (default: theme: in config)")
 
 }
 
@@ -227,4 +273,6 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Println("Using config file:", viper.ConfigFileUsed())
 	}
+
+	applyTagsConfig()
 }
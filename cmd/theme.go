@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/hysios/todo/parser"
+	"github.com/hysios/todo/printer"
+	"github.com/spf13/viper"
+)
+
+var theme string
+
+// tagConfig is the shape of a `tags.<name>` entry in .todo.yaml, e.g.:
+//
+//	tags:
+//	  blocked:
+//	    color: [FgRed, Bold]
+//	    type: critical
+type tagConfig struct {
+	Color []string `mapstructure:"color"`
+	Type  string   `mapstructure:"type"`
+}
+
+var tagFamilies = map[string]parser.TagType{
+	"critical": parser.TagCritical,
+	"high":     parser.TagHigh,
+	"low":      parser.TagLow,
+	"today":    parser.TagToday,
+	"done":     parser.TagDone,
+	"started":  parser.TagStarted,
+	"est":      parser.TagEst,
+	"lasted":   parser.TagLasted,
+	"normal":   parser.TagNormal,
+}
+
+// applyTagsConfig reads `tags.*` from viper and registers each one with
+// the parser (and, if it sets its own color, the printer) so `@<name>` is
+// recognized dynamically instead of only the hard-coded built-in set.
+func applyTagsConfig() {
+	var tags map[string]tagConfig
+	if err := viper.UnmarshalKey("tags", &tags); err != nil {
+		return
+	}
+
+	for name, cfg := range tags {
+		base, ok := tagFamilies[strings.ToLower(cfg.Type)]
+		if !ok {
+			base = parser.TagNormal
+		}
+		tagType := parser.RegisterTag(name, base)
+
+		if len(cfg.Color) == 0 {
+			continue
+		}
+
+		var colors []printer.Color
+		for _, name := range cfg.Color {
+			if c, ok := printer.ParseColorName(name); ok {
+				colors = append(colors, c)
+			}
+		}
+		if len(colors) > 0 {
+			printer.RegisterTagColor(tagType, colors)
+		}
+	}
+}
+
+// themeName resolves the active theme: --theme wins, falling back to the
+// `theme:` config key.
+func themeName() string {
+	if theme != "" {
+		return theme
+	}
+	return viper.GetString("theme")
+}
+
+// applyConfiguredTheme overlays the resolved theme's colors (from
+// `themes.<name>` in .todo.yaml) onto print's palette.
+func applyConfiguredTheme(print *printer.Printer) {
+	name := themeName()
+	if name == "" {
+		return
+	}
+
+	var colors map[string][]string
+	if err := viper.UnmarshalKey("themes."+name, &colors); err != nil {
+		return
+	}
+
+	print.ApplyTheme(colors)
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/hysios/todo/parser"
+	"github.com/hysios/todo/printer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var watch bool
+
+// watchDebounce coalesces the burst of write events a single save
+// typically produces into one re-render.
+const watchDebounce = 150 * time.Millisecond
+
+// watchCmd is the explicit `todo watch` entry point; the same behaviour is
+// also available as `--watch`/`-W` on the root command.
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch todo files and re-render whenever they change",
+	Run: func(cmd *cobra.Command, args []string) {
+		cwd, err := os.Getwd()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		files := inputs
+		if len(files) == 0 {
+			files, err = lookupTodos(cwd)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		if err := watchTodos(files); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.PersistentFlags().BoolVarP(&watch, "watch", "W", false, "watch todo files and re-render whenever they change")
+}
+
+// watchTodos renders files once, then keeps re-rendering on every
+// debounced write event until the watcher errors out or is closed. It also
+// starts viper's own config watch so a palette/theme change in the config
+// file takes effect on the next render without restarting the process.
+func watchTodos(files []string) error {
+	if len(files) == 0 {
+		return fmt.Errorf("no todo files to watch")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	for _, name := range files {
+		if err := watcher.Add(name); err != nil {
+			return err
+		}
+	}
+
+	viper.OnConfigChange(func(fsnotify.Event) { applyTagsConfig() })
+	viper.WatchConfig()
+
+	render := func() { renderWatch(files, time.Now()) }
+	render()
+
+	var debounce *time.Timer
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+
+			if debounce != nil {
+				debounce.Stop()
+			}
+			debounce = time.AfterFunc(watchDebounce, render)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Println("watch:", err)
+		}
+	}
+}
+
+func renderWatch(files []string, changed time.Time) {
+	clearScreen()
+
+	var pending, done, cancelled int
+	for _, name := range files {
+		todo, err := parseTodo(name)
+		if err != nil {
+			log.Println("watch:", err)
+			continue
+		}
+
+		print := printer.New(todo)
+		applyConfiguredTheme(print)
+		if track {
+			print.AddPipe(printer.TimeTrackPipe())
+		}
+		f, err := formatterFor(format, print)
+		if err != nil {
+			log.Println("watch:", err)
+			continue
+		}
+		if err := f.Format(todo, os.Stdout); err != nil {
+			log.Println("watch:", err)
+		}
+
+		countStatuses(todo.Items, &pending, &done, &cancelled)
+	}
+
+	fmt.Println(strings.Repeat("-", 40))
+	fmt.Printf("%s | updated %s | pending:%d done:%d cancelled:%d\n",
+		strings.Join(files, ", "), changed.Format("15:04:05"), pending, done, cancelled)
+}
+
+func clearScreen() {
+	fmt.Print("\033[H\033[2J")
+}
+
+func countStatuses(items []*parser.Todoitem, pending, done, cancelled *int) {
+	for _, item := range items {
+		switch item.Status {
+		case parser.StDone:
+			*done++
+		case parser.StCancel:
+			*cancelled++
+		case parser.StPending, parser.StStarted:
+			*pending++
+		}
+		countStatuses(item.Items, pending, done, cancelled)
+	}
+}
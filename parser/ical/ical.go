@@ -0,0 +1,142 @@
+// Package ical exports a parser.Todofile as an iCalendar document, one
+// VTODO component per item-type Todoitem (titles and free text get none),
+// with nested Items linked via RELATED-TO.
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hysios/todo/parser"
+)
+
+// Export writes file to w as a complete iCalendar (.ics) document.
+func Export(w io.Writer, file *parser.Todofile) error {
+	fmt.Fprintln(w, "BEGIN:VCALENDAR")
+	fmt.Fprintln(w, "VERSION:2.0")
+	fmt.Fprintln(w, "PRODID:-//hysios/todo//EN")
+
+	counter := 0
+	for _, item := range file.Items {
+		writeVTodo(w, item, "", &counter)
+	}
+
+	fmt.Fprintln(w, "END:VCALENDAR")
+	return nil
+}
+
+// writeVTodo emits one VTODO per parser.ItItem, walking non-item nodes
+// (titles, free text) without emitting a component of their own. counter is
+// a file-wide UID sequence shared across the whole recursion, since Items
+// resets per sibling list and would otherwise produce duplicate UIDs across
+// sections. parentUID is the UID of the nearest enclosing VTODO, or "" at
+// the top level; RELATED-TO is only written when that parent actually
+// exists.
+func writeVTodo(w io.Writer, item *parser.Todoitem, parentUID string, counter *int) {
+	if item.Type != parser.ItItem {
+		for _, child := range item.Items {
+			writeVTodo(w, child, parentUID, counter)
+		}
+		return
+	}
+
+	uid := fmt.Sprintf("%d@todo", *counter)
+	*counter++
+
+	fmt.Fprintln(w, "BEGIN:VTODO")
+	fmt.Fprintf(w, "UID:%s\n", uid)
+	fmt.Fprintf(w, "SUMMARY:%s\n", escapeText(item.Text))
+	fmt.Fprintf(w, "STATUS:%s\n", vtodoStatus(item.Status))
+	if started, ok := tagTime(item, parser.TagStarted); ok {
+		fmt.Fprintf(w, "DTSTART:%s\n", formatTime(started))
+	}
+	if done, ok := tagTime(item, parser.TagDone); ok {
+		fmt.Fprintf(w, "COMPLETED:%s\n", formatTime(done))
+	}
+	if p, ok := vtodoPriority(item.Tags); ok {
+		fmt.Fprintf(w, "PRIORITY:%d\n", p)
+	}
+	if cats := categories(item.Tags); cats != "" {
+		fmt.Fprintf(w, "CATEGORIES:%s\n", cats)
+	}
+	if parentUID != "" {
+		fmt.Fprintf(w, "RELATED-TO:%s\n", parentUID)
+	}
+	fmt.Fprintln(w, "END:VTODO")
+
+	for _, child := range item.Items {
+		writeVTodo(w, child, uid, counter)
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func vtodoStatus(status parser.ItemStatus) string {
+	switch status {
+	case parser.StDone:
+		return "COMPLETED"
+	case parser.StCancel:
+		return "CANCELLED"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// vtodoPriority maps the @critical/@high/@low family to the iCalendar
+// 1(highest)-9(lowest) priority scale.
+func vtodoPriority(tags []parser.Tag) (int, bool) {
+	for _, tag := range tags {
+		switch parser.TagFamily(tag.Type) {
+		case parser.TagCritical:
+			return 1, true
+		case parser.TagHigh:
+			return 5, true
+		case parser.TagLow:
+			return 9, true
+		}
+	}
+	return 0, false
+}
+
+// categories turns the remaining @tags into a CATEGORIES list, excluding
+// the ones rendered as dedicated VTODO properties above as well as
+// @est, @lasted and @today.
+func categories(tags []parser.Tag) string {
+	var cats []string
+	for _, tag := range tags {
+		switch parser.TagFamily(tag.Type) {
+		case parser.TagDone, parser.TagStarted, parser.TagEst, parser.TagLasted,
+			parser.TagCritical, parser.TagHigh, parser.TagLow, parser.TagToday:
+			continue
+		}
+
+		if len(tag.Text) == 0 || tag.Text[0] != '@' {
+			continue
+		}
+		name := tag.Text[1:]
+		if i := strings.IndexByte(name, '('); i >= 0 {
+			name = name[:i]
+		}
+		cats = append(cats, name)
+	}
+	return strings.Join(cats, ",")
+}
+
+func tagTime(item *parser.Todoitem, typ parser.TagType) (time.Time, bool) {
+	for _, tag := range item.Tags {
+		if parser.TagFamily(tag.Type) == typ {
+			return parser.ParseTagTime(tag.TagValue())
+		}
+	}
+	return time.Time{}, false
+}
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\n", `\n`)
+
+func escapeText(s string) string {
+	return icalEscaper.Replace(s)
+}
@@ -0,0 +1,73 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hysios/todo/parser"
+)
+
+func TestExportUniqueUIDsAcrossSections(t *testing.T) {
+	mkItem := func(text string) *parser.Todoitem {
+		return &parser.Todoitem{Type: parser.ItItem, Text: text}
+	}
+
+	file := &parser.Todofile{
+		Items: []*parser.Todoitem{
+			{Type: parser.ItTitle, Text: "Section A:", Items: []*parser.Todoitem{
+				mkItem("task a1"),
+				mkItem("task a2"),
+			}},
+			{Type: parser.ItTitle, Text: "Section B:", Items: []*parser.Todoitem{
+				mkItem("task b1"),
+				mkItem("task b2"),
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Export(&buf, file); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if !strings.HasPrefix(line, "UID:") {
+			continue
+		}
+		if seen[line] {
+			t.Fatalf("duplicate UID emitted: %s", line)
+		}
+		seen[line] = true
+	}
+	if len(seen) != 4 {
+		t.Fatalf("expected 4 unique UIDs, got %d", len(seen))
+	}
+}
+
+func TestExportRelatedToOnlyForChildren(t *testing.T) {
+	file := &parser.Todofile{
+		Items: []*parser.Todoitem{
+			{Type: parser.ItItem, Text: "top", Items: []*parser.Todoitem{
+				{Type: parser.ItItem, Text: "child"},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Export(&buf, file); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	vtodos := strings.Split(buf.String(), "BEGIN:VTODO")[1:]
+	if len(vtodos) != 2 {
+		t.Fatalf("expected 2 VTODOs, got %d", len(vtodos))
+	}
+	if strings.Contains(vtodos[0], "RELATED-TO") {
+		t.Fatalf("top-level VTODO must not have RELATED-TO:\n%s", vtodos[0])
+	}
+	if !strings.Contains(vtodos[1], "RELATED-TO") {
+		t.Fatalf("child VTODO must have RELATED-TO:\n%s", vtodos[1])
+	}
+}
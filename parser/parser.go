@@ -7,6 +7,7 @@ import (
 	"io"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -57,10 +58,69 @@ const (
 	TagItalic
 	TagDeleted
 	TagCode
+	TagAssignee
 
 	TagUnknown
 )
 
+// TagCustomBase is the first TagType value available to tags registered at
+// runtime via RegisterTag (e.g. a user-defined `tags.blocked` config
+// entry), keeping them out of the way of the built-in constants above.
+const TagCustomBase TagType = TagUnknown + 1
+
+var (
+	customTagsMu   sync.Mutex
+	customTagNames = map[string]TagType{}
+	customTagBases = map[TagType]TagType{}
+	nextCustomTag  = TagCustomBase
+)
+
+// RegisterTag makes tagType recognize an @name not in its hard-coded set,
+// e.g. RegisterTag("blocked", TagCritical) makes "@blocked" parse and
+// color the same as "@critical". It returns the TagType allocated for
+// name, idempotently if called again with the same name.
+func RegisterTag(name string, base TagType) TagType {
+	customTagsMu.Lock()
+	defer customTagsMu.Unlock()
+
+	name = strings.ToLower(name)
+	if t, ok := customTagNames[name]; ok {
+		customTagBases[t] = base
+		return t
+	}
+
+	t := nextCustomTag
+	nextCustomTag++
+	customTagNames[name] = t
+	customTagBases[t] = base
+	return t
+}
+
+// TagFamily returns the semantic family a TagType should be treated as:
+// itself for a built-in TagType, or the base passed to RegisterTag for a
+// dynamically registered one.
+func TagFamily(t TagType) TagType {
+	customTagsMu.Lock()
+	defer customTagsMu.Unlock()
+
+	if base, ok := customTagBases[t]; ok {
+		return base
+	}
+	return t
+}
+
+func lookupCustomTag(name string) (TagType, bool) {
+	customTagsMu.Lock()
+	defer customTagsMu.Unlock()
+
+	for tagName, t := range customTagNames {
+		if strings.HasPrefix(name, tagName) {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
 type Tag struct {
 	Start, Stop int
 	Type        TagType
@@ -90,6 +150,48 @@ func (state ItemStatus) MarshalJSON() ([]byte, error) {
 	return json.Marshal(state.String())
 }
 
+var tagTypeNames = map[TagType]string{
+	TagNormal:   "Normal",
+	TagTime:     "Time",
+	TagDone:     "Done",
+	TagStarted:  "Started",
+	TagLasted:   "Lasted",
+	TagEst:      "Est",
+	TagCritical: "Critical",
+	TagHigh:     "High",
+	TagLow:      "Low",
+	TagToday:    "Today",
+	TagBold:     "Bold",
+	TagItalic:   "Italic",
+	TagDeleted:  "Deleted",
+	TagCode:     "Code",
+	TagAssignee: "Assignee",
+	TagUnknown:  "Unknown",
+}
+
+// String renders t by name, falling back to the @name it was registered
+// under for a tag allocated at runtime via RegisterTag (or a bare
+// "Custom(N)" label if even that can't be found).
+func (t TagType) String() string {
+	if name, ok := tagTypeNames[t]; ok {
+		return name
+	}
+
+	customTagsMu.Lock()
+	defer customTagsMu.Unlock()
+	for name, ct := range customTagNames {
+		if ct == t {
+			return name
+		}
+	}
+
+	return fmt.Sprintf("Custom(%d)", int(t))
+}
+
+func (t TagType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
 func Parse(filename string, r io.Reader) (*Todofile, error) {
 	var (
 		todofile           Todofile
@@ -354,6 +456,12 @@ func tagType(tag string) TagType {
 		return TagLow
 	} else if strings.HasPrefix(tag, "today") {
 		return TagToday
+	} else if strings.HasPrefix(tag, "assignee") {
+		return TagAssignee
+	}
+
+	if t, ok := lookupCustomTag(tag); ok {
+		return t
 	}
 
 	return TagNormal
@@ -375,12 +483,25 @@ func parseTag(text string, node *Todoitem) []Tag {
 			Text:  text[i0:i1],
 		}
 		tags = append(tags, tag)
+
+		if tag.Type == TagAssignee {
+			if m := reAssignee.FindStringSubmatch(tag.Text); len(m) == 2 {
+				node.Assignees = append(node.Assignees, Tag{
+					Start: tag.Start,
+					Stop:  tag.Stop,
+					Type:  TagAssignee,
+					Text:  m[1],
+				})
+			}
+		}
 	}
 
 	node.Tags = append(node.Tags, tags...)
 	return tags
 }
 
+var reAssignee = regexp.MustCompile(`^@assignee\(([\w\d\s:-]+)\)$`)
+
 func formatTag(text string) TagType {
 	switch text[0] {
 	case '*':
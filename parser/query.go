@@ -0,0 +1,412 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query is a boolean expression over a Todoitem's fields, @tags, and
+// derived dates, compiled once by ParseQuery and then applied repeatedly
+// via Match or Filter. It underlies the `filter` subcommand.
+//
+// Supported predicates:
+//
+//	status:pending        item.Status equals the named status
+//	type:item             item.Type equals the named type
+//	text:/regex/           item.Text matches the regexp
+//	@tag                   item has a tag whose name starts with "tag"
+//	assignee:name          item has an @assignee(name) tag
+//	before:2006-01-02      item has a @done/@started/@est tag before the date
+//	after:2006-01-02       item has a @done/@started/@est tag after the date
+//	ident<=2               item.Ident compares against an int (<=,>=,<,>,=)
+//
+// combined with "and", "or", "not", and parenthesized grouping.
+type Query struct {
+	root queryExpr
+}
+
+type queryExpr interface {
+	match(item *Todoitem) bool
+}
+
+type predicate func(item *Todoitem) bool
+
+func (p predicate) match(item *Todoitem) bool { return p(item) }
+
+type andExpr struct{ left, right queryExpr }
+
+func (e *andExpr) match(item *Todoitem) bool { return e.left.match(item) && e.right.match(item) }
+
+type orExpr struct{ left, right queryExpr }
+
+func (e *orExpr) match(item *Todoitem) bool { return e.left.match(item) || e.right.match(item) }
+
+type notExpr struct{ inner queryExpr }
+
+func (e *notExpr) match(item *Todoitem) bool { return !e.inner.match(item) }
+
+// ParseQuery compiles a query expression. See Query for the supported
+// grammar.
+func ParseQuery(expr string) (*Query, error) {
+	p := &queryParser{tokens: tokenizeQuery(expr)}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok != "" {
+		return nil, fmt.Errorf("parser: unexpected token %q in query", tok)
+	}
+	return &Query{root: root}, nil
+}
+
+// Match reports whether item itself satisfies the query, ignoring its
+// children.
+func (q *Query) Match(item *Todoitem) bool {
+	return q.root.match(item)
+}
+
+// Filter walks file and returns a new Todofile containing only items that
+// match the query (or, when flatten is false, whose descendants match —
+// in which case the ancestor chain is kept so the pruned tree still prints
+// correctly). When flatten is true, every matching item is promoted to the
+// top level of the returned Todofile regardless of original nesting.
+func (q *Query) Filter(file *Todofile, flatten bool) *Todofile {
+	var out Todofile
+
+	if flatten {
+		for _, item := range file.Items {
+			q.collectFlat(item, &out.Items)
+		}
+		return &out
+	}
+
+	for _, item := range file.Items {
+		if pruned := q.prune(item); pruned != nil {
+			out.Items = append(out.Items, pruned)
+		}
+	}
+	return &out
+}
+
+func (q *Query) collectFlat(item *Todoitem, out *[]*Todoitem) {
+	if q.Match(item) {
+		clone := *item
+		clone.parent = nil
+		clone.Items = nil
+		*out = append(*out, &clone)
+	}
+
+	for _, child := range item.Items {
+		q.collectFlat(child, out)
+	}
+}
+
+// prune returns a clone of item with non-matching branches removed, or nil
+// if neither item nor any of its descendants match.
+func (q *Query) prune(item *Todoitem) *Todoitem {
+	var kept []*Todoitem
+	for _, child := range item.Items {
+		if pruned := q.prune(child); pruned != nil {
+			kept = append(kept, pruned)
+		}
+	}
+
+	if !q.Match(item) && len(kept) == 0 {
+		return nil
+	}
+
+	clone := *item
+	clone.parent = nil
+	clone.Items = kept
+	return &clone
+}
+
+// --- tokenizer ---
+
+func tokenizeQuery(expr string) []string {
+	var tokens []string
+
+	i := 0
+	for i < len(expr) {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			start := i
+			if strings.HasPrefix(expr[i:], "text:/") {
+				// A text:/regex/ predicate is scanned as one atomic token
+				// up to its closing "/", so parens inside the regex (e.g.
+				// "text:/fix(ed)?/") aren't mistaken for grouping.
+				i += len("text:/")
+				for i < len(expr) && expr[i] != '/' {
+					i++
+				}
+				if i < len(expr) {
+					i++ // consume the closing /
+				}
+			} else {
+				for i < len(expr) && !isQueryBreak(expr[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, expr[start:i])
+		}
+	}
+
+	return tokens
+}
+
+func isQueryBreak(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '(' || c == ')'
+}
+
+// --- recursive-descent parser: or > and > not > primary ---
+
+type queryParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *queryParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *queryParser) parseOr() (queryExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for strings.EqualFold(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for strings.EqualFold(p.peek(), "and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) parseNot() (queryExpr, error) {
+	if strings.EqualFold(p.peek(), "not") {
+		p.next()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{inner: inner}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryExpr, error) {
+	switch tok := p.peek(); tok {
+	case "":
+		return nil, fmt.Errorf("parser: unexpected end of query")
+	case "(":
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("parser: expected ) in query")
+		}
+		p.next()
+		return inner, nil
+	default:
+		p.next()
+		return parsePredicate(tok)
+	}
+}
+
+// --- predicates ---
+
+var reIdentCmp = regexp.MustCompile(`^ident(<=|>=|<|>|=)(\d+)$`)
+
+func parsePredicate(tok string) (queryExpr, error) {
+	switch {
+	case strings.HasPrefix(tok, "@"):
+		name := strings.ToLower(tok[1:])
+		return predicate(func(item *Todoitem) bool { return hasTagNamed(item, name) }), nil
+	case strings.HasPrefix(tok, "status:"):
+		st, err := parseStatusName(tok[len("status:"):])
+		if err != nil {
+			return nil, err
+		}
+		return predicate(func(item *Todoitem) bool { return item.Status == st }), nil
+	case strings.HasPrefix(tok, "type:"):
+		it, err := parseTypeName(tok[len("type:"):])
+		if err != nil {
+			return nil, err
+		}
+		return predicate(func(item *Todoitem) bool { return item.Type == it }), nil
+	case strings.HasPrefix(tok, "text:"):
+		pattern := strings.Trim(tok[len("text:"):], "/")
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("parser: invalid text: regexp %q: %w", pattern, err)
+		}
+		return predicate(func(item *Todoitem) bool { return re.MatchString(item.Text) }), nil
+	case strings.HasPrefix(tok, "assignee:"):
+		name := strings.ToLower(tok[len("assignee:"):])
+		return predicate(func(item *Todoitem) bool { return hasAssignee(item, name) }), nil
+	case strings.HasPrefix(tok, "before:"):
+		t, err := parseQueryDate(tok[len("before:"):])
+		if err != nil {
+			return nil, err
+		}
+		return predicate(func(item *Todoitem) bool { return matchDate(item, func(v time.Time) bool { return v.Before(t) }) }), nil
+	case strings.HasPrefix(tok, "after:"):
+		t, err := parseQueryDate(tok[len("after:"):])
+		if err != nil {
+			return nil, err
+		}
+		return predicate(func(item *Todoitem) bool { return matchDate(item, func(v time.Time) bool { return v.After(t) }) }), nil
+	case reIdentCmp.MatchString(tok):
+		return parseIdentPredicate(tok)
+	default:
+		return nil, fmt.Errorf("parser: unrecognized predicate %q", tok)
+	}
+}
+
+func hasTagNamed(item *Todoitem, name string) bool {
+	for _, tag := range item.Tags {
+		if len(tag.Text) == 0 || tag.Text[0] != '@' {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(tag.Text[1:]), name) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAssignee(item *Todoitem, name string) bool {
+	for _, a := range item.Assignees {
+		if strings.ToLower(a.Text) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func matchDate(item *Todoitem, ok func(time.Time) bool) bool {
+	for _, tag := range item.Tags {
+		if tag.Type != TagDone && tag.Type != TagStarted && tag.Type != TagEst {
+			continue
+		}
+		if v, found := ParseTagTime(tag.TagValue()); found && ok(v) {
+			return true
+		}
+	}
+	return false
+}
+
+var statusNames = map[string]ItemStatus{
+	"unknown":   StUnknown,
+	"pending":   StPending,
+	"started":   StStarted,
+	"done":      StDone,
+	"cancel":    StCancel,
+	"cancelled": StCancel,
+	"canceled":  StCancel,
+	"archive":   StArchive,
+}
+
+func parseStatusName(s string) (ItemStatus, error) {
+	st, ok := statusNames[strings.ToLower(s)]
+	if !ok {
+		return 0, fmt.Errorf("parser: unknown status %q", s)
+	}
+	return st, nil
+}
+
+var typeNames = map[string]ItemType{
+	"text":  ItText,
+	"item":  ItItem,
+	"title": ItTitle,
+}
+
+func parseTypeName(s string) (ItemType, error) {
+	it, ok := typeNames[strings.ToLower(s)]
+	if !ok {
+		return 0, fmt.Errorf("parser: unknown type %q", s)
+	}
+	return it, nil
+}
+
+var queryDateLayouts = []string{"2006-01-02", "2006-01-02 15:04"}
+
+func parseQueryDate(s string) (time.Time, error) {
+	for _, layout := range queryDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("parser: invalid date %q", s)
+}
+
+func parseIdentPredicate(tok string) (queryExpr, error) {
+	m := reIdentCmp.FindStringSubmatch(tok)
+	if m == nil {
+		return nil, fmt.Errorf("parser: invalid ident predicate %q", tok)
+	}
+
+	op := m[1]
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return predicate(func(item *Todoitem) bool {
+		switch op {
+		case "<=":
+			return item.Ident <= n
+		case ">=":
+			return item.Ident >= n
+		case "<":
+			return item.Ident < n
+		case ">":
+			return item.Ident > n
+		default:
+			return item.Ident == n
+		}
+	}), nil
+}
@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenizeQueryPreservesRegexParens(t *testing.T) {
+	got := tokenizeQuery(`text:/fix(ed)?/ and not @done`)
+	want := []string{"text:/fix(ed)?/", "and", "not", "@done"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("tokenizeQuery() = %#v, want %#v", got, want)
+	}
+}
+
+func TestParseQueryWithGroupedRegex(t *testing.T) {
+	q, err := ParseQuery(`text:/fix(ed)?/`)
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+
+	if !q.Match(&Todoitem{Text: "fixed the bug"}) {
+		t.Fatalf("expected match against %q", "fixed the bug")
+	}
+	if !q.Match(&Todoitem{Text: "fix the bug"}) {
+		t.Fatalf("expected match against %q", "fix the bug")
+	}
+	if q.Match(&Todoitem{Text: "broken"}) {
+		t.Fatalf("expected no match against %q", "broken")
+	}
+}
+
+func TestParseQueryAndOrPrecedence(t *testing.T) {
+	// "and" should bind tighter than "or": ident=10 or ident>=2 and ident<=4
+	// means ident=10 or (ident>=2 and ident<=4), not (ident=10 or ident>=2)
+	// and ident<=4, which would reject ident=10.
+	q, err := ParseQuery("ident=10 or ident>=2 and ident<=4")
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+
+	for _, tc := range []struct {
+		ident int
+		want  bool
+	}{
+		{1, false},
+		{3, true},
+		{4, true},
+		{5, false},
+		{10, true},
+	} {
+		if got := q.Match(&Todoitem{Ident: tc.ident}); got != tc.want {
+			t.Errorf("ident=%d: Match() = %v, want %v", tc.ident, got, tc.want)
+		}
+	}
+}
+
+func TestParseQueryNot(t *testing.T) {
+	q, err := ParseQuery("not status:done")
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+
+	if q.Match(&Todoitem{Status: StDone}) {
+		t.Fatalf("expected no match for a done item")
+	}
+	if !q.Match(&Todoitem{Status: StPending}) {
+		t.Fatalf("expected match for a pending item")
+	}
+}
+
+func TestParseQueryDatePredicate(t *testing.T) {
+	q, err := ParseQuery("before:2024-06-01")
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+
+	early := &Todoitem{Tags: []Tag{{Type: TagDone, Text: "@done(2024-01-02)"}}}
+	late := &Todoitem{Tags: []Tag{{Type: TagDone, Text: "@done(2024-12-31)"}}}
+
+	if !q.Match(early) {
+		t.Fatalf("expected match for a @done date before the cutoff")
+	}
+	if q.Match(late) {
+		t.Fatalf("expected no match for a @done date after the cutoff")
+	}
+}
+
+func TestQueryFilterPruneKeepsAncestorChain(t *testing.T) {
+	q, err := ParseQuery("status:done")
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+
+	child := &Todoitem{Type: ItItem, Text: "child", Status: StDone}
+	parent := &Todoitem{Type: ItTitle, Text: "Section:", Items: []*Todoitem{child}}
+	file := &Todofile{Items: []*Todoitem{parent}}
+
+	pruned := q.Filter(file, false)
+	if len(pruned.Items) != 1 {
+		t.Fatalf("expected the ancestor title to survive pruning, got %d top-level items", len(pruned.Items))
+	}
+	if pruned.Items[0].Text != "Section:" {
+		t.Fatalf("expected pruned tree to keep the title node, got %q", pruned.Items[0].Text)
+	}
+	if len(pruned.Items[0].Items) != 1 || pruned.Items[0].Items[0].Text != "child" {
+		t.Fatalf("expected the matching child to remain nested under its title")
+	}
+}
+
+func TestQueryFilterFlattenPromotesMatches(t *testing.T) {
+	q, err := ParseQuery("status:done")
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+
+	child := &Todoitem{Type: ItItem, Text: "child", Status: StDone}
+	parent := &Todoitem{Type: ItTitle, Text: "Section:", Items: []*Todoitem{child}}
+	file := &Todofile{Items: []*Todoitem{parent}}
+
+	flat := q.Filter(file, true)
+	if len(flat.Items) != 1 || flat.Items[0].Text != "child" {
+		t.Fatalf("expected flatten to promote the matching child to the top level, got %+v", flat.Items)
+	}
+	if len(flat.Items[0].Items) != 0 {
+		t.Fatalf("expected flattened items to have no children")
+	}
+}
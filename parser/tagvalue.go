@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+var reTagValue = regexp.MustCompile(`\(([^)]*)\)`)
+
+// TagValue returns the text inside a tag's parentheses, e.g. "2024-01-02
+// 10:00" for a tag whose Text is "@done(2024-01-02 10:00)". It returns ""
+// for tags with no parenthesized value (e.g. "@today").
+func (tag Tag) TagValue() string {
+	m := reTagValue.FindStringSubmatch(tag.Text)
+	if len(m) != 2 {
+		return ""
+	}
+	return strings.TrimSpace(m[1])
+}
+
+// tagTimeLayouts are the layouts tried, in order, when parsing a tag value
+// as a timestamp.
+var tagTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTagTime parses a tag value (as returned by Tag.TagValue) using the
+// layouts recognized by @done/@started/@est tags.
+func ParseTagTime(value string) (time.Time, bool) {
+	for _, layout := range tagTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Duration returns the elapsed time between this item's @started and @done
+// tags, or zero if either tag is missing, unparsable, or @done precedes
+// @started.
+func (node *Todoitem) Duration() time.Duration {
+	started, ok := node.tagTime(TagStarted)
+	if !ok {
+		return 0
+	}
+
+	done, ok := node.tagTime(TagDone)
+	if !ok || done.Before(started) {
+		return 0
+	}
+
+	return done.Sub(started)
+}
+
+func (node *Todoitem) tagTime(typ TagType) (time.Time, bool) {
+	for _, tag := range node.Tags {
+		if tag.Type == typ {
+			return ParseTagTime(tag.TagValue())
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,148 @@
+// Package todotxt converts between the native indent-based todo format
+// and the flat todo.txt format (https://github.com/todotxt/todo.txt).
+package todotxt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/hysios/todo/parser"
+)
+
+var (
+	reDone       = regexp.MustCompile(`^x\s+`)
+	rePriority   = regexp.MustCompile(`^\(([A-Z])\)\s*`)
+	reProject    = regexp.MustCompile(`\+(\S+)`)
+	reContext    = regexp.MustCompile(`@(\S+)`)
+	reDue        = regexp.MustCompile(`(?:^|\s)due:(\S+)`)
+	reWhitespace = regexp.MustCompile(`\s+`)
+)
+
+// Parse reads todo.txt formatted lines from r. todo.txt has no nesting, so
+// every line becomes one top-level Todoitem; `+project`, `@context`, and
+// `due:` key-values are preserved as Tags so they round-trip through
+// Write. Write always re-emits them trailing the text, so a line's word
+// order relative to its inline tags is not preserved across a round trip.
+func Parse(r io.Reader) (*parser.Todofile, error) {
+	var file parser.Todofile
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := s.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		file.Items = append(file.Items, parseLine(line))
+	}
+
+	return &file, s.Err()
+}
+
+func parseLine(line string) *parser.Todoitem {
+	item := &parser.Todoitem{
+		Type:  parser.ItItem,
+		Items: make([]*parser.Todoitem, 0),
+	}
+
+	text := line
+	if reDone.MatchString(text) {
+		item.Status = parser.StDone
+		text = reDone.ReplaceAllString(text, "")
+	} else {
+		item.Status = parser.StPending
+	}
+	item.Token = statusToken(item.Status)
+
+	if m := rePriority.FindStringSubmatch(text); m != nil {
+		item.Tags = append(item.Tags, parser.Tag{Type: parser.TagNormal, Text: "(" + m[1] + ")"})
+		text = rePriority.ReplaceAllString(text, "")
+	}
+
+	for _, m := range reProject.FindAllStringSubmatch(text, -1) {
+		item.Tags = append(item.Tags, parser.Tag{Type: parser.TagNormal, Text: "+" + m[1]})
+	}
+	for _, m := range reContext.FindAllStringSubmatch(text, -1) {
+		item.Tags = append(item.Tags, parser.Tag{Type: parser.TagNormal, Text: "@" + m[1]})
+	}
+	for _, m := range reDue.FindAllStringSubmatch(text, -1) {
+		item.Tags = append(item.Tags, parser.Tag{Type: parser.TagEst, Text: "due:" + m[1]})
+	}
+
+	text = reProject.ReplaceAllString(text, "")
+	text = reContext.ReplaceAllString(text, "")
+	text = reDue.ReplaceAllString(text, "")
+	// Removing tags from the middle of the line leaves behind the
+	// whitespace that used to separate them; collapse it so stripped
+	// tokens don't turn into stray double spaces.
+	item.Text = strings.TrimSpace(reWhitespace.ReplaceAllString(text, " "))
+
+	return item
+}
+
+func statusToken(status parser.ItemStatus) string {
+	if status == parser.StDone {
+		return "x"
+	}
+	return "-"
+}
+
+// Write renders file back to todo.txt, reconstructing each line from its
+// Text plus the +project/@context/due:/priority Tags Parse attached to it.
+func Write(w io.Writer, file *parser.Todofile) error {
+	for _, item := range flatten(file.Items) {
+		if err := writeLine(w, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flatten walks nested Items depth-first, since todo.txt has no nesting of
+// its own.
+func flatten(items []*parser.Todoitem) []*parser.Todoitem {
+	var out []*parser.Todoitem
+	for _, item := range items {
+		if item.Type == parser.ItItem {
+			out = append(out, item)
+		}
+		out = append(out, flatten(item.Items)...)
+	}
+	return out
+}
+
+func writeLine(w io.Writer, item *parser.Todoitem) error {
+	var (
+		parts    []string
+		priority string
+		trailer  []string
+	)
+
+	if item.Status == parser.StDone {
+		parts = append(parts, "x")
+	}
+
+	for _, tag := range item.Tags {
+		if strings.HasPrefix(tag.Text, "(") {
+			priority = tag.Text
+		}
+	}
+	if priority != "" {
+		parts = append(parts, priority)
+	}
+
+	parts = append(parts, item.Text)
+
+	for _, tag := range item.Tags {
+		if strings.HasPrefix(tag.Text, "(") {
+			continue
+		}
+		trailer = append(trailer, tag.Text)
+	}
+	parts = append(parts, trailer...)
+
+	_, err := fmt.Fprintln(w, strings.Join(parts, " "))
+	return err
+}
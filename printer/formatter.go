@@ -0,0 +1,56 @@
+package printer
+
+import (
+	"io"
+
+	"github.com/hysios/todo/parser"
+)
+
+// Formatter renders a parser.Todofile to w in some output format. It
+// replaces the old Print/WriteTo split: every output backend (color
+// terminal, plain text, JSON, Markdown, HTML, ...) implements this single
+// interface so callers can pick one with a `--format` flag instead of
+// calling a dedicated method per format.
+type Formatter interface {
+	Format(todofile *parser.Todofile, w io.Writer) error
+}
+
+// colorFormatter renders ANSI-colored text using a Printer's palette and
+// pipes, identical to the pre-existing Printer.Print behaviour.
+type colorFormatter struct {
+	print *Printer
+}
+
+func (f *colorFormatter) Format(todofile *parser.Todofile, w io.Writer) error {
+	f.print.todofile = todofile
+	for _, child := range todofile.Items {
+		f.print.printNodePipes(child, w)
+	}
+	return nil
+}
+
+// plainFormatter renders uncolored text, identical to the pre-existing
+// Printer.WriteTo behaviour.
+type plainFormatter struct {
+	print *Printer
+}
+
+func (f *plainFormatter) Format(todofile *parser.Todofile, w io.Writer) error {
+	f.print.todofile = todofile
+	for _, child := range todofile.Items {
+		f.print.printNodePipesWithoutColor(child, w)
+	}
+	return nil
+}
+
+// ColorFormatter returns a Formatter that renders ANSI-colored text using
+// this Printer's palette and pipes.
+func (print *Printer) ColorFormatter() Formatter {
+	return &colorFormatter{print: print}
+}
+
+// PlainFormatter returns a Formatter that renders uncolored text using this
+// Printer's pipes.
+func (print *Printer) PlainFormatter() Formatter {
+	return &plainFormatter{print: print}
+}
@@ -0,0 +1,237 @@
+package printer
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/hysios/todo/parser"
+)
+
+// HTMLFormatter renders a Todofile as a standalone HTML document: a nested
+// <ul>/<li> tree with CSS classes (`.tag-critical`, `.status-done`, ...)
+// taken from the existing ItemType/TagType palette entries, plus a
+// stylesheet embedded in <head> so the output needs no external assets.
+type HTMLFormatter struct {
+	Palette map[ItemType][]Color
+}
+
+// NewHTMLFormatter builds an HTMLFormatter whose stylesheet is derived from
+// palette (typically a Printer's configured theme).
+func NewHTMLFormatter(palette map[ItemType][]Color) *HTMLFormatter {
+	return &HTMLFormatter{Palette: palette}
+}
+
+func (f *HTMLFormatter) Format(todofile *parser.Todofile, w io.Writer) error {
+	fmt.Fprint(w, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprint(w, f.stylesheet())
+	fmt.Fprint(w, "</head>\n<body>\n<ul class=\"todo\">\n")
+	for _, child := range todofile.Items {
+		writeHTMLNode(child, w)
+	}
+	fmt.Fprint(w, "</ul>\n</body>\n</html>\n")
+	return nil
+}
+
+// paletteClassNames maps the printer's internal ItemType palette keys to
+// the CSS classes an HTML consumer would actually want to style.
+var paletteClassNames = map[ItemType]string{
+	ClItem:      "item",
+	ClTitle:     "title",
+	ClText:      "text",
+	ClDone:      "status-done",
+	ClCancel:    "status-cancel",
+	ClTag:       "tag",
+	ClCritical:  "tag-critical",
+	ClHigh:      "tag-high",
+	ClLow:       "tag-low",
+	ClToday:     "tag-today",
+	ClBold:      "tag-bold",
+	ClItalic:    "tag-italic",
+	ClDeleted:   "tag-deleted",
+	ClHighlight: "tag-code",
+}
+
+// attrToCSS translates the subset of fatih/color attributes used by
+// defaultPalette into CSS declarations. Attributes with no sensible CSS
+// equivalent (reset codes, etc.) are simply omitted from the stylesheet.
+var attrToCSS = map[color.Attribute]string{
+	color.FgBlack:    "color:#000000",
+	color.FgRed:      "color:#e74c3c",
+	color.FgGreen:    "color:#2ecc71",
+	color.FgYellow:   "color:#f1c40f",
+	color.FgMagenta:  "color:#9b59b6",
+	color.FgWhite:    "color:#ffffff",
+	color.FgCyan:     "color:#1abc9c",
+	color.FgHiRed:    "color:#ff6b6b",
+	color.FgHiYellow: "color:#f7dc6f",
+	color.FgHiCyan:   "color:#5ddcea",
+	color.FgHiWhite:  "color:#ffffff",
+	color.BgRed:      "background-color:#e74c3c",
+	color.BgHiRed:    "background-color:#ff6b6b",
+	color.BgHiCyan:   "background-color:#5ddcea",
+	color.BgYellow:   "background-color:#f1c40f",
+	color.BgMagenta:  "background-color:#9b59b6",
+	color.Bold:       "font-weight:bold",
+	color.Faint:      "opacity:0.6",
+	color.Italic:     "font-style:italic",
+	color.CrossedOut: "text-decoration:line-through",
+}
+
+func (f *HTMLFormatter) stylesheet() string {
+	var sb strings.Builder
+	sb.WriteString("<style>\n")
+	for typ, class := range paletteClassNames {
+		colors, ok := f.Palette[typ]
+		if !ok {
+			continue
+		}
+
+		var decls []string
+		for _, c := range colors {
+			if css, ok := attrToCSS[c]; ok {
+				decls = append(decls, css)
+			}
+		}
+		if len(decls) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&sb, ".%s { %s; }\n", class, strings.Join(decls, "; "))
+	}
+
+	// A tag given its own color via RegisterTagColor lives in a dynamic
+	// ItemType outside paletteClassNames, so it needs its own rule keyed on
+	// the same class tagClassName would emit for it.
+	for tagTyp, it := range customTagColors {
+		colors, ok := f.Palette[it]
+		if !ok {
+			continue
+		}
+
+		var decls []string
+		for _, c := range colors {
+			if css, ok := attrToCSS[c]; ok {
+				decls = append(decls, css)
+			}
+		}
+		if len(decls) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&sb, ".%s { %s; }\n", customTagClassName(tagTyp), strings.Join(decls, "; "))
+	}
+
+	sb.WriteString("</style>\n")
+	return sb.String()
+}
+
+func writeHTMLNode(node *parser.Todoitem, w io.Writer) {
+	fmt.Fprintf(w, "<li class=\"%s\">%s", statusClassName(node.Status), htmlText(node))
+	if len(node.Items) > 0 {
+		fmt.Fprint(w, "\n<ul>\n")
+		for _, child := range node.Items {
+			writeHTMLNode(child, w)
+		}
+		fmt.Fprint(w, "</ul>\n")
+	}
+	fmt.Fprint(w, "</li>\n")
+}
+
+func statusClassName(status parser.ItemStatus) string {
+	switch status {
+	case parser.StDone:
+		return "status-done"
+	case parser.StCancel:
+		return "status-cancel"
+	case parser.StStarted:
+		return "status-started"
+	case parser.StArchive:
+		return "status-archive"
+	default:
+		return "status-pending"
+	}
+}
+
+// tagClassName mirrors printer.tagClr's precedence - a tag given its own
+// color via RegisterTagColor wins, otherwise fall back to the built-in
+// family - so a config-defined @tag (e.g. tags.blocked: {type: critical})
+// gets the same distinction in HTML as it gets in the terminal printer.
+func tagClassName(tagTyp parser.TagType) string {
+	if _, ok := customTagColors[tagTyp]; ok {
+		return customTagClassName(tagTyp)
+	}
+
+	switch parser.TagFamily(tagTyp) {
+	case parser.TagCritical:
+		return "tag-critical"
+	case parser.TagHigh:
+		return "tag-high"
+	case parser.TagLow:
+		return "tag-low"
+	case parser.TagToday:
+		return "tag-today"
+	case parser.TagDone:
+		return "tag-done"
+	case parser.TagStarted:
+		return "tag-started"
+	case parser.TagEst:
+		return "tag-est"
+	case parser.TagLasted:
+		return "tag-lasted"
+	case parser.TagBold:
+		return "tag-bold"
+	case parser.TagItalic:
+		return "tag-italic"
+	case parser.TagDeleted:
+		return "tag-deleted"
+	case parser.TagCode:
+		return "tag-code"
+	default:
+		return "tag"
+	}
+}
+
+// customTagClassName derives the CSS class for a TagType carrying its own
+// RegisterTagColor entry, e.g. "tag-blocked" for a tag registered from a
+// tags.blocked config entry.
+func customTagClassName(tagTyp parser.TagType) string {
+	return "tag-" + strings.ToLower(tagTyp.String())
+}
+
+// htmlText escapes node.Text and wraps each parsed @tag/format span in a
+// <span class="tag-..."> so the stylesheet above can color it. A span that
+// overlaps one already wrapped is left unwrapped.
+func htmlText(node *parser.Todoitem) string {
+	if len(node.Tags) == 0 {
+		return html.EscapeString(node.Text)
+	}
+
+	var (
+		sb   strings.Builder
+		text = node.Text
+		j    int
+	)
+
+	// @tags and format spans are parsed in separate passes, so visit them
+	// in text order.
+	tags := append([]parser.Tag(nil), node.Tags...)
+	sort.SliceStable(tags, func(a, b int) bool { return tags[a].Start < tags[b].Start })
+
+	for _, tag := range tags {
+		if tag.Start < j || tag.Start > len(text) || tag.Stop > len(text) {
+			continue
+		}
+		sb.WriteString(html.EscapeString(text[j:tag.Start]))
+		fmt.Fprintf(&sb, "<span class=\"%s\">%s</span>", tagClassName(tag.Type), html.EscapeString(tag.Text))
+		j = tag.Stop
+	}
+	if j < len(text) {
+		sb.WriteString(html.EscapeString(text[j:]))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,25 @@
+package printer
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/hysios/todo/parser"
+)
+
+// JSONFormatter serializes the full Todofile/Todoitem tree as JSON,
+// relying on ItemType/ItemStatus's MarshalJSON to render enums as their
+// stringer names rather than bare ints.
+type JSONFormatter struct {
+	// Indent, when non-empty, is used as the per-level indent passed to
+	// json.Encoder.SetIndent. Leave empty for compact output.
+	Indent string
+}
+
+func (f *JSONFormatter) Format(todofile *parser.Todofile, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	if f.Indent != "" {
+		enc.SetIndent("", f.Indent)
+	}
+	return enc.Encode(todofile)
+}
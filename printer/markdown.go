@@ -0,0 +1,48 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hysios/todo/parser"
+)
+
+// MarkdownFormatter renders a Todofile as a GitHub-Flavored Markdown task
+// list (`- [ ]` / `- [x]`), preserving indent depth and @tag text verbatim.
+type MarkdownFormatter struct{}
+
+func (f *MarkdownFormatter) Format(todofile *parser.Todofile, w io.Writer) error {
+	for _, child := range todofile.Items {
+		writeMarkdownNode(child, w)
+	}
+	return nil
+}
+
+func writeMarkdownNode(node *parser.Todoitem, w io.Writer) {
+	indent := strings.Repeat(" ", node.Ident)
+
+	switch node.Type {
+	case parser.ItItem:
+		fmt.Fprintf(w, "%s- [%s] %s\n", indent, markdownBox(node.Status), node.Text)
+	case parser.ItTitle:
+		fmt.Fprintf(w, "%s**%s**\n", indent, strings.TrimSuffix(node.Text, ":"))
+	default:
+		fmt.Fprintf(w, "%s%s\n", indent, node.Text)
+	}
+
+	for _, child := range node.Items {
+		writeMarkdownNode(child, w)
+	}
+}
+
+func markdownBox(status parser.ItemStatus) string {
+	switch status {
+	case parser.StDone:
+		return "x"
+	case parser.StCancel:
+		return "-"
+	default:
+		return " "
+	}
+}
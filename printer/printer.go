@@ -29,14 +29,15 @@ const (
 	ClTime
 	ClUser
 	ClHighlight
-	ClCustom1
-	ClCustom2
-	ClCustom3
-	ClCustom4
+	ClCritical
+	ClHigh
+	ClLow
+	ClToday
 
 	ClBold
 	ClItalic
 	ClDeleted
+	ClDuration
 )
 
 type Color = color.Attribute
@@ -58,14 +59,15 @@ var defaultPalette = map[ItemType][]Color{
 	ClDone:      []Color{color.FgGreen},
 	ClCancel:    []Color{color.FgRed},
 	ClTag:       []Color{color.FgYellow},
-	ClCustom1:   []Color{color.BgHiRed, color.FgBlack},
-	ClCustom2:   []Color{color.BgHiCyan, color.FgBlack},
-	ClCustom3:   []Color{color.BgYellow, color.FgBlack},
-	ClCustom4:   []Color{color.BgMagenta, color.FgBlack},
+	ClCritical:  []Color{color.BgHiRed, color.FgBlack},
+	ClHigh:      []Color{color.BgHiCyan, color.FgBlack},
+	ClLow:       []Color{color.BgYellow, color.FgBlack},
+	ClToday:     []Color{color.BgMagenta, color.FgBlack},
 	ClBold:      []Color{color.Bold},
 	ClItalic:    []Color{color.Italic},
 	ClDeleted:   []Color{color.CrossedOut},
 	ClHighlight: []Color{color.FgHiYellow},
+	ClDuration:  []Color{color.FgMagenta},
 }
 
 func New(todofile *parser.Todofile) *Printer {
@@ -75,6 +77,7 @@ func New(todofile *parser.Todofile) *Printer {
 	}
 
 	mergo.Merge(&p.Palette, defaultPalette)
+	mergo.Merge(&p.Palette, customPalette)
 
 	return p
 }
@@ -104,17 +107,21 @@ func statuClr(status parser.ItemStatus) ItemType {
 }
 
 func tagClr(tagTyp parser.TagType) ItemType {
-	switch tagTyp {
+	if it, ok := customTagColors[tagTyp]; ok {
+		return it
+	}
+
+	switch parser.TagFamily(tagTyp) {
 	case parser.TagNormal:
 		return ClTag
 	case parser.TagCritical:
-		return ClCustom1
+		return ClCritical
 	case parser.TagHigh:
-		return ClCustom2
+		return ClHigh
 	case parser.TagLow:
-		return ClCustom3
+		return ClLow
 	case parser.TagToday:
-		return ClCustom4
+		return ClToday
 	case parser.TagBold:
 		return ClBold
 	case parser.TagItalic:
@@ -253,7 +260,13 @@ func (print *Printer) printColour(node *parser.Todoitem, w io.Writer) {
 		})
 		fmt.Fprintf(w, "%s%s %s\n", strings.Repeat(" ", node.Ident), cStat.Sprint(node.Token), mainText)
 	} else {
-		fmt.Fprintf(w, "%s%s\n", strings.Repeat(" ", node.Ident), ctxt.Sprint(node.Text))
+		text := ctxt.Sprint(node.Text)
+		if node.Type == parser.ItTitle {
+			if total := sumDoneDurations(node.Items); total > 0 {
+				text += " " + print.pickColor(ClDuration).Sprint("("+formatDuration(total)+")")
+			}
+		}
+		fmt.Fprintf(w, "%s%s\n", strings.Repeat(" ", node.Ident), text)
 	}
 }
 
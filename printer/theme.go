@@ -0,0 +1,127 @@
+package printer
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/hysios/todo/parser"
+)
+
+// customItemBase is the first ItemType value available for tag colors
+// registered at runtime via RegisterTagColor, mirroring the dynamic
+// TagType range in package parser.
+const customItemBase ItemType = ClDuration + 1
+
+var (
+	nextCustomItem  = customItemBase
+	customTagColors = map[parser.TagType]ItemType{}
+	customPalette   = map[ItemType][]Color{}
+)
+
+// RegisterTagColor gives tagType its own Palette entry, so a config-defined
+// tags.<name>.color overrides the color its "type" family would otherwise
+// fall back to. Call it before printer.New for the color to be included
+// in newly constructed Printers; calling it again for the same tagType
+// updates the color in place.
+func RegisterTagColor(tagType parser.TagType, colors []Color) ItemType {
+	it, ok := customTagColors[tagType]
+	if !ok {
+		it = nextCustomItem
+		nextCustomItem++
+		customTagColors[tagType] = it
+	}
+	customPalette[it] = colors
+	return it
+}
+
+// paletteKeyNames maps a palette entry's config-file spelling (as used in
+// `themes.<name>.<key>`) to its ItemType.
+var paletteKeyNames = map[string]ItemType{
+	"ClBase":      ClBase,
+	"ClItem":      ClItem,
+	"ClTitle":     ClTitle,
+	"ClText":      ClText,
+	"ClDone":      ClDone,
+	"ClCancel":    ClCancel,
+	"ClTag":       ClTag,
+	"ClCritical":  ClCritical,
+	"ClHigh":      ClHigh,
+	"ClLow":       ClLow,
+	"ClToday":     ClToday,
+	"ClBold":      ClBold,
+	"ClItalic":    ClItalic,
+	"ClDeleted":   ClDeleted,
+	"ClHighlight": ClHighlight,
+	"ClDuration":  ClDuration,
+}
+
+var colorAttrNames = map[string]Color{
+	"FgBlack":    color.FgBlack,
+	"FgRed":      color.FgRed,
+	"FgGreen":    color.FgGreen,
+	"FgYellow":   color.FgYellow,
+	"FgBlue":     color.FgBlue,
+	"FgMagenta":  color.FgMagenta,
+	"FgCyan":     color.FgCyan,
+	"FgWhite":    color.FgWhite,
+	"FgHiRed":    color.FgHiRed,
+	"FgHiGreen":  color.FgHiGreen,
+	"FgHiYellow": color.FgHiYellow,
+	"FgHiCyan":   color.FgHiCyan,
+	"FgHiWhite":  color.FgHiWhite,
+	"BgBlack":    color.BgBlack,
+	"BgRed":      color.BgRed,
+	"BgGreen":    color.BgGreen,
+	"BgYellow":   color.BgYellow,
+	"BgBlue":     color.BgBlue,
+	"BgMagenta":  color.BgMagenta,
+	"BgCyan":     color.BgCyan,
+	"BgWhite":    color.BgWhite,
+	"BgHiRed":    color.BgHiRed,
+	"BgHiCyan":   color.BgHiCyan,
+	"Bold":       color.Bold,
+	"Faint":      color.Faint,
+	"Italic":     color.Italic,
+	"Underline":  color.Underline,
+	"CrossedOut": color.CrossedOut,
+}
+
+// ParseColorName resolves a config color name (e.g. "FgRed", "Bold") to a
+// fatih/color attribute.
+func ParseColorName(name string) (Color, bool) {
+	c, ok := colorAttrNames[name]
+	return c, ok
+}
+
+// ApplyTheme merges a named theme's colors (as loaded from config, e.g.
+// `themes.dark: {ClCritical: [BgRed, FgWhite]}`) into p's palette.
+// Unknown palette keys or color names are skipped so one typo doesn't
+// break the rest of the theme.
+func (p *Printer) ApplyTheme(theme map[string][]string) {
+	for key, names := range theme {
+		// viper hands config keys over lowercased, so match them without
+		// regard to case.
+		it, ok := paletteKeyNames[key]
+		if !ok {
+			for name, typ := range paletteKeyNames {
+				if strings.EqualFold(name, key) {
+					it, ok = typ, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		var colors []Color
+		for _, name := range names {
+			if c, ok := ParseColorName(name); ok {
+				colors = append(colors, c)
+			}
+		}
+		if len(colors) > 0 {
+			p.Palette[it] = colors
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hysios/todo/parser"
+)
+
+// TimeTrackPipe returns a PrinterFunc that derives @lasted(Xh Ym) from a
+// node's @started/@done tags and injects it into node.Text when the item
+// doesn't already carry one. It's added to a Printer the same way
+// cmd.regeneratorNumber is: via Printer.AddPipe, so rewriteTodo persists
+// the inserted tag back into the source file.
+func TimeTrackPipe() PrinterFunc {
+	return func(node *parser.Todoitem, w io.Writer) {
+		if node.Type != parser.ItItem || hasLastedTag(node) {
+			return
+		}
+
+		d := node.Duration()
+		if d <= 0 {
+			return
+		}
+
+		lasted := "@lasted(" + formatDuration(d) + ")"
+		// tagStart/tagStop are the tag's real position in node.Text as it
+		// stands right now (post auto-number, if that pipe already ran).
+		// printColour re-applies node.Offset() to every tag via shiftTag,
+		// including this one, so it has to be stored net of that offset -
+		// otherwise a pipe ordering like regeneratorNumber before
+		// TimeTrackPipe double-shifts the freshly appended tag.
+		tagStart := len(node.Text) + 1
+		node.Text = node.Text + " " + lasted
+		node.Tags = append(node.Tags, parser.Tag{
+			Start: tagStart - node.Offset(),
+			Stop:  tagStart + len(lasted) - node.Offset(),
+			Type:  parser.TagLasted,
+			Text:  lasted,
+		})
+	}
+}
+
+func hasLastedTag(node *parser.Todoitem) bool {
+	for _, tag := range node.Tags {
+		if tag.Type == parser.TagLasted {
+			return true
+		}
+	}
+	return false
+}
+
+// sumDoneDurations sums Duration() across every descendant item whose
+// status is done, for rendering roll-up totals on ItTitle nodes.
+func sumDoneDurations(items []*parser.Todoitem) time.Duration {
+	var total time.Duration
+	for _, item := range items {
+		if item.Status == parser.StDone {
+			total += item.Duration()
+		}
+		total += sumDoneDurations(item.Items)
+	}
+	return total
+}
+
+func formatDuration(d time.Duration) string {
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+
+	switch {
+	case h > 0 && m > 0:
+		return fmt.Sprintf("%dh %dm", h, m)
+	case h > 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dm", m)
+	}
+}